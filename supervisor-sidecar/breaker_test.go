@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerTripsAfterThreshold(t *testing.T) {
+	b := newCircuitBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if !b.allow() {
+			t.Fatalf("expected allow() before threshold reached")
+		}
+		b.recordFailure()
+	}
+	if b.String() != "closed" {
+		t.Fatalf("state = %s, want closed before threshold", b.String())
+	}
+
+	b.recordFailure()
+	if b.String() != "open" {
+		t.Fatalf("state = %s, want open after threshold failures", b.String())
+	}
+	if b.allow() {
+		t.Fatalf("expected allow() to reject while open and within cooldown")
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbe(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond)
+
+	if !b.allow() {
+		t.Fatalf("expected initial allow()")
+	}
+	b.recordFailure()
+	if b.String() != "open" {
+		t.Fatalf("state = %s, want open", b.String())
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !b.allow() {
+		t.Fatalf("expected allow() to admit a probe once cooldown elapsed")
+	}
+	if b.String() != "half-open" {
+		t.Fatalf("state = %s, want half-open", b.String())
+	}
+	if b.allow() {
+		t.Fatalf("expected concurrent allow() to reject while a probe is in flight")
+	}
+}
+
+func TestCircuitBreakerHalfOpenSuccessCloses(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond)
+	b.allow()
+	b.recordFailure()
+	time.Sleep(20 * time.Millisecond)
+	b.allow()
+
+	b.recordSuccess()
+	if b.String() != "closed" {
+		t.Fatalf("state = %s, want closed after successful probe", b.String())
+	}
+	if !b.allow() {
+		t.Fatalf("expected allow() once closed again")
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailureReopens(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond)
+	b.allow()
+	b.recordFailure()
+	time.Sleep(20 * time.Millisecond)
+	b.allow()
+
+	b.recordFailure()
+	if b.String() != "open" {
+		t.Fatalf("state = %s, want open after failed probe", b.String())
+	}
+}
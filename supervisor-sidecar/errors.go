@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"time"
+)
+
+// Error codes surfaced in probeError.Code, so consumers can branch
+// programmatically instead of parsing English messages.
+const (
+	CodeCoreUnreachable  = "CORE_UNREACHABLE"
+	CodeCoreTimeout      = "CORE_TIMEOUT"
+	CodeCoreBadStatus    = "CORE_BAD_STATUS"
+	CodeDecodeFailed     = "DECODE_FAILED"
+	CodeSignatureInvalid = "SIGNATURE_INVALID"
+	CodeSplitBrain       = "SPLIT_BRAIN"
+)
+
+// probeError is the typed replacement for the old string-concatenated
+// Errors []string: one entry per probe failure, with enough structure for
+// callers to alert or retry on.
+type probeError struct {
+	Code      string    `json:"code"`
+	Target    string    `json:"target,omitempty"`
+	Phase     string    `json:"phase"`
+	Message   string    `json:"message"`
+	Retryable bool      `json:"retryable"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e *probeError) Error() string { return e.Code + ": " + e.Message }
+
+// classifyProbeError wraps err, observed for targetName during phase
+// ("health" or "status"), into a typed probeError.
+func classifyProbeError(targetName, phase string, err error) *probeError {
+	pe := &probeError{
+		Target:    targetName,
+		Phase:     phase,
+		Message:   err.Error(),
+		Timestamp: time.Now().UTC(),
+	}
+
+	var httpErr *httpError
+	var sigErr *signatureError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &sigErr):
+		pe.Code = CodeSignatureInvalid
+		pe.Retryable = false
+	case errors.As(err, &httpErr):
+		pe.Code = CodeCoreBadStatus
+		pe.Retryable = httpErr.StatusCode >= 500
+	case errors.As(err, &netErr) && netErr.Timeout():
+		pe.Code = CodeCoreTimeout
+		pe.Retryable = true
+	case isDecodeError(err):
+		pe.Code = CodeDecodeFailed
+		pe.Retryable = false
+	default:
+		pe.Code = CodeCoreUnreachable
+		pe.Retryable = true
+	}
+	return pe
+}
+
+func isDecodeError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+)
+
+type fakeTimeoutError struct{}
+
+func (fakeTimeoutError) Error() string   { return "i/o timeout" }
+func (fakeTimeoutError) Timeout() bool   { return true }
+func (fakeTimeoutError) Temporary() bool { return true }
+
+var _ net.Error = fakeTimeoutError{}
+
+func TestClassifyProbeError(t *testing.T) {
+	cases := []struct {
+		name          string
+		err           error
+		wantCode      string
+		wantRetryable bool
+	}{
+		{
+			name:          "signature error",
+			err:           &signatureError{msg: "signature verification failed"},
+			wantCode:      CodeSignatureInvalid,
+			wantRetryable: false,
+		},
+		{
+			name:          "5xx http error is retryable",
+			err:           &httpError{StatusCode: 503},
+			wantCode:      CodeCoreBadStatus,
+			wantRetryable: true,
+		},
+		{
+			name:          "4xx http error is not retryable",
+			err:           &httpError{StatusCode: 404},
+			wantCode:      CodeCoreBadStatus,
+			wantRetryable: false,
+		},
+		{
+			name:          "network timeout",
+			err:           fakeTimeoutError{},
+			wantCode:      CodeCoreTimeout,
+			wantRetryable: true,
+		},
+		{
+			name:          "json syntax error",
+			err:           &json.SyntaxError{},
+			wantCode:      CodeDecodeFailed,
+			wantRetryable: false,
+		},
+		{
+			name:          "unrecognized error falls back to unreachable",
+			err:           errors.New("connection refused"),
+			wantCode:      CodeCoreUnreachable,
+			wantRetryable: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pe := classifyProbeError("core", "health", tc.err)
+			if pe.Code != tc.wantCode {
+				t.Errorf("Code = %q, want %q", pe.Code, tc.wantCode)
+			}
+			if pe.Retryable != tc.wantRetryable {
+				t.Errorf("Retryable = %v, want %v", pe.Retryable, tc.wantRetryable)
+			}
+			if pe.Target != "core" || pe.Phase != "health" {
+				t.Errorf("Target/Phase = %q/%q, want core/health", pe.Target, pe.Phase)
+			}
+		})
+	}
+}
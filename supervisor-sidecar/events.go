@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Event types published to the event bus; consumers branch on Type.
+const (
+	EventCoreReachable          = "core_reachable"
+	EventCoreUnreachable        = "core_unreachable"
+	EventEnforcementModeChanged = "enforcement_mode_changed"
+	EventBreakerOpened          = "breaker_opened"
+	EventBreakerClosed          = "breaker_closed"
+	EventSplitBrain             = "split_brain"
+)
+
+type event struct {
+	ID   int64       `json:"id"`
+	Type string      `json:"type"`
+	Time time.Time   `json:"time"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// eventBus fans out state-transition events to SSE subscribers and retains
+// a bounded backlog so a `?since=` reconnect doesn't miss events.
+type eventBus struct {
+	mu          sync.Mutex
+	nextID      int64
+	backlog     []event
+	maxBacklog  int
+	subscribers map[chan event]struct{}
+}
+
+func newEventBus(maxBacklog int) *eventBus {
+	return &eventBus{
+		maxBacklog:  maxBacklog,
+		subscribers: make(map[chan event]struct{}),
+	}
+}
+
+func (b *eventBus) publish(typ string, data interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	ev := event{ID: b.nextID, Type: typ, Time: time.Now().UTC(), Data: data}
+
+	b.backlog = append(b.backlog, ev)
+	if len(b.backlog) > b.maxBacklog {
+		b.backlog = b.backlog[len(b.backlog)-b.maxBacklog:]
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- ev:
+		default:
+			// Slow subscriber; drop the event rather than block publishers.
+		}
+	}
+}
+
+func (b *eventBus) subscribe() (ch chan event, unsubscribe func()) {
+	ch = make(chan event, 32)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+		close(ch)
+	}
+}
+
+// since returns backlog events with ID strictly greater than id.
+func (b *eventBus) since(id int64) []event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.backlogSince(id)
+}
+
+// subscribeSince registers ch as a live subscriber and returns the backlog
+// events with ID > id in the same critical section, so a publish landing
+// between "replay the backlog" and "start receiving live events" can't be
+// both replayed from the backlog and delivered again on ch.
+func (b *eventBus) subscribeSince(id int64) (ch chan event, backlog []event, unsubscribe func()) {
+	ch = make(chan event, 32)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	backlog = b.backlogSince(id)
+	b.mu.Unlock()
+
+	return ch, backlog, func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+		close(ch)
+	}
+}
+
+// backlogSince returns backlog events with ID strictly greater than id.
+// Callers must hold b.mu.
+func (b *eventBus) backlogSince(id int64) []event {
+	var out []event
+	for _, ev := range b.backlog {
+		if ev.ID > id {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+func writeSSEEvent(w http.ResponseWriter, ev event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", ev.ID, payload)
+	return err
+}
+
+// handleEvents streams state-transition events as Server-Sent Events. A
+// `?since=N` query param replays backlog events with ID > N before
+// switching to live streaming.
+func handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	var since int64
+	if v := r.URL.Query().Get("since"); v != "" {
+		since, _ = strconv.ParseInt(v, 10, 64)
+	}
+
+	ch, backlog, unsubscribe := events.subscribeSince(since)
+	defer unsubscribe()
+
+	for _, ev := range backlog {
+		if err := writeSSEEvent(w, ev); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-ch:
+			if err := writeSSEEvent(w, ev); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
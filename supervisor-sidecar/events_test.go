@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventBusSinceReturnsEventsAfterID(t *testing.T) {
+	b := newEventBus(16)
+	b.publish(EventCoreReachable, nil)
+	b.publish(EventCoreUnreachable, nil)
+	b.publish(EventBreakerOpened, nil)
+
+	got := b.since(1)
+	if len(got) != 2 {
+		t.Fatalf("len(since(1)) = %d, want 2", len(got))
+	}
+	if got[0].Type != EventCoreUnreachable || got[1].Type != EventBreakerOpened {
+		t.Fatalf("since(1) = %+v, want [core_unreachable, breaker_opened]", got)
+	}
+
+	if got := b.since(3); len(got) != 0 {
+		t.Fatalf("len(since(3)) = %d, want 0", len(got))
+	}
+}
+
+func TestEventBusTrimsBacklogToMax(t *testing.T) {
+	b := newEventBus(2)
+	b.publish(EventCoreReachable, nil)
+	b.publish(EventCoreUnreachable, nil)
+	b.publish(EventBreakerOpened, nil)
+
+	got := b.since(0)
+	if len(got) != 2 {
+		t.Fatalf("len(backlog) = %d, want 2 after trimming to maxBacklog", len(got))
+	}
+	if got[0].Type != EventCoreUnreachable || got[1].Type != EventBreakerOpened {
+		t.Fatalf("backlog = %+v, want the 2 most recent events", got)
+	}
+}
+
+// TestEventBusSubscribeSinceDoesNotDuplicate guards against the race where a
+// separate subscribe() + since() pair could deliver the same event twice: once
+// from the backlog replay, once off the live channel, if a publish landed in
+// the gap between the two calls.
+func TestEventBusSubscribeSinceDoesNotDuplicate(t *testing.T) {
+	b := newEventBus(16)
+	b.publish(EventCoreReachable, nil)
+
+	ch, backlog, unsubscribe := b.subscribeSince(0)
+	defer unsubscribe()
+	if len(backlog) != 1 || backlog[0].Type != EventCoreReachable {
+		t.Fatalf("backlog = %+v, want [core_reachable]", backlog)
+	}
+
+	b.publish(EventBreakerOpened, nil)
+
+	select {
+	case ev := <-ch:
+		if ev.Type != EventBreakerOpened {
+			t.Fatalf("ch delivered %+v, want breaker_opened", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected breaker_opened on the live channel")
+	}
+
+	for _, ev := range backlog {
+		if ev.Type == EventBreakerOpened {
+			t.Fatalf("breaker_opened present in the backlog snapshot taken at subscribe time: delivered twice")
+		}
+	}
+}
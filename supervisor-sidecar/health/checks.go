@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// SignatureVerifier checks a detached signature (e.g. the core's
+// X-FrostGate-Signature header) over a response body. It lets HTTPJSONCheck
+// enforce the same trust policy as the poller without importing its
+// concrete verifier type.
+type SignatureVerifier interface {
+	Verify(body []byte, header string) error
+}
+
+// HTTPJSONCheck hits a JSON endpoint and is healthy if the request succeeds
+// with a non-error status code. It's used for the core's /health and
+// /status endpoints. Client should already be configured with whatever TLS
+// policy (mTLS, pinning) the target requires; if SigVerifier is set, the
+// response's X-FrostGate-Signature header is verified before the check
+// passes.
+type HTTPJSONCheck struct {
+	CheckName   string
+	URL         string
+	Client      *http.Client
+	SigVerifier SignatureVerifier
+}
+
+func (c *HTTPJSONCheck) Name() string { return c.CheckName }
+
+func (c *HTTPJSONCheck) Healthy(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s: status %d", c.URL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if c.SigVerifier != nil {
+		if err := c.SigVerifier.Verify(body, resp.Header.Get("X-FrostGate-Signature")); err != nil {
+			return fmt.Errorf("%s: %w", c.URL, err)
+		}
+	}
+
+	var discard map[string]interface{}
+	return json.Unmarshal(body, &discard)
+}
+
+// DiskSpaceCheck is healthy if the filesystem backing Path has at least
+// MinFreeBytes available.
+type DiskSpaceCheck struct {
+	Path         string
+	MinFreeBytes uint64
+}
+
+func (c *DiskSpaceCheck) Name() string { return "disk_space" }
+
+func (c *DiskSpaceCheck) Healthy(ctx context.Context) error {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(c.Path, &stat); err != nil {
+		return fmt.Errorf("statfs %s: %w", c.Path, err)
+	}
+	free := stat.Bavail * uint64(stat.Bsize)
+	if free < c.MinFreeBytes {
+		return fmt.Errorf("%s has %d bytes free, want >= %d", c.Path, free, c.MinFreeBytes)
+	}
+	return nil
+}
+
+// TLSCertExpiryCheck is healthy if the leaf certificate served at Addr does
+// not expire within WarnBefore.
+type TLSCertExpiryCheck struct {
+	Addr       string
+	WarnBefore time.Duration
+}
+
+func (c *TLSCertExpiryCheck) Name() string { return "tls_cert_expiry" }
+
+func (c *TLSCertExpiryCheck) Healthy(ctx context.Context) error {
+	dialer := tls.Dialer{Config: &tls.Config{}}
+	conn, err := dialer.DialContext(ctx, "tcp", c.Addr)
+	if err != nil {
+		return fmt.Errorf("dial %s: %w", c.Addr, err)
+	}
+	defer conn.Close()
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return fmt.Errorf("%s: not a TLS connection", c.Addr)
+	}
+	certs := tlsConn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return fmt.Errorf("%s: no peer certificates presented", c.Addr)
+	}
+	expiry := certs[0].NotAfter
+	if time.Until(expiry) < c.WarnBefore {
+		return fmt.Errorf("%s: certificate expires %s", c.Addr, expiry.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// AnchorReachabilityCheck is healthy if the configured upstream anchor
+// endpoint responds without error.
+type AnchorReachabilityCheck struct {
+	URL    string
+	Client *http.Client
+}
+
+func (c *AnchorReachabilityCheck) Name() string { return "anchor_reachability" }
+
+func (c *AnchorReachabilityCheck) Healthy(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s: status %d", c.URL, resp.StatusCode)
+	}
+	return nil
+}
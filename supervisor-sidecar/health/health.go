@@ -0,0 +1,119 @@
+// Package health implements a pluggable readiness/liveness subsystem for the
+// supervisor-sidecar, modeled on the CoreOS-style Checker pattern: concrete
+// checks implement Checkable, the Checker aggregates them, and HTTP handlers
+// expose the result to orchestrators.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Checkable is a single named health probe.
+type Checkable interface {
+	Name() string
+	Healthy(ctx context.Context) error
+}
+
+// CheckStatus is the point-in-time result of one registered check.
+type CheckStatus struct {
+	Name                string    `json:"name"`
+	Critical            bool      `json:"critical"`
+	Healthy             bool      `json:"healthy"`
+	Error               string    `json:"error,omitempty"`
+	LatencyMS           int64     `json:"latency_ms"`
+	LastSuccess         time.Time `json:"last_success,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+}
+
+type registration struct {
+	check    Checkable
+	critical bool
+
+	mu                  sync.Mutex
+	lastSuccess         time.Time
+	consecutiveFailures int
+}
+
+// Checker aggregates a set of Checkable probes, each marked critical or
+// advisory. Critical failures fail readiness; advisory failures degrade it.
+type Checker struct {
+	mu   sync.Mutex
+	regs []*registration
+}
+
+// NewChecker returns an empty Checker ready for Register calls.
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Register adds check to the set probed by RunAll/Ready. critical checks
+// must pass for Ready to report true; advisory checks may fail without
+// affecting readiness.
+func (c *Checker) Register(check Checkable, critical bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.regs = append(c.regs, &registration{check: check, critical: critical})
+}
+
+// RunAll executes every registered check concurrently and returns their
+// statuses in registration order.
+func (c *Checker) RunAll(ctx context.Context) []CheckStatus {
+	c.mu.Lock()
+	regs := make([]*registration, len(c.regs))
+	copy(regs, c.regs)
+	c.mu.Unlock()
+
+	statuses := make([]CheckStatus, len(regs))
+	var wg sync.WaitGroup
+	for i, reg := range regs {
+		wg.Add(1)
+		go func(i int, reg *registration) {
+			defer wg.Done()
+			statuses[i] = reg.run(ctx)
+		}(i, reg)
+	}
+	wg.Wait()
+	return statuses
+}
+
+// Ready runs every registered check and reports whether the aggregate is
+// ready: true only if every critical check is healthy. Advisory failures are
+// still reported in statuses but do not flip ready to false.
+func (c *Checker) Ready(ctx context.Context) (ready bool, statuses []CheckStatus) {
+	statuses = c.RunAll(ctx)
+	ready = true
+	for _, s := range statuses {
+		if s.Critical && !s.Healthy {
+			ready = false
+		}
+	}
+	return ready, statuses
+}
+
+func (r *registration) run(ctx context.Context) CheckStatus {
+	start := time.Now()
+	err := r.check.Healthy(ctx)
+	latency := time.Since(start)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := CheckStatus{
+		Name:      r.check.Name(),
+		Critical:  r.critical,
+		Healthy:   err == nil,
+		LatencyMS: latency.Milliseconds(),
+	}
+	if err != nil {
+		status.Error = err.Error()
+		r.consecutiveFailures++
+	} else {
+		r.lastSuccess = time.Now().UTC()
+		r.consecutiveFailures = 0
+	}
+	status.LastSuccess = r.lastSuccess
+	status.ConsecutiveFailures = r.consecutiveFailures
+	return status
+}
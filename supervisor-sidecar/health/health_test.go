@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeCheck struct {
+	name string
+	err  error
+}
+
+func (c *fakeCheck) Name() string { return c.name }
+
+func (c *fakeCheck) Healthy(ctx context.Context) error { return c.err }
+
+func TestCheckerReadyCriticalFailureFailsReadiness(t *testing.T) {
+	c := NewChecker()
+	c.Register(&fakeCheck{name: "core_health"}, true)
+	c.Register(&fakeCheck{name: "disk_space", err: errors.New("disk full")}, false)
+
+	ready, statuses := c.Ready(context.Background())
+	if !ready {
+		t.Fatalf("ready = false, want true when only an advisory check fails")
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("len(statuses) = %d, want 2", len(statuses))
+	}
+
+	c2 := NewChecker()
+	c2.Register(&fakeCheck{name: "core_health", err: errors.New("unreachable")}, true)
+	c2.Register(&fakeCheck{name: "disk_space"}, false)
+
+	ready, statuses = c2.Ready(context.Background())
+	if ready {
+		t.Fatalf("ready = true, want false when a critical check fails")
+	}
+	for _, s := range statuses {
+		if s.Name == "core_health" && s.Healthy {
+			t.Fatalf("core_health reported healthy, want unhealthy")
+		}
+	}
+}
+
+func TestCheckerReadyTracksConsecutiveFailures(t *testing.T) {
+	check := &fakeCheck{name: "flaky", err: errors.New("boom")}
+	c := NewChecker()
+	c.Register(check, false)
+
+	for i := 1; i <= 3; i++ {
+		_, statuses := c.Ready(context.Background())
+		if got := statuses[0].ConsecutiveFailures; got != i {
+			t.Fatalf("ConsecutiveFailures = %d, want %d", got, i)
+		}
+	}
+
+	check.err = nil
+	_, statuses := c.Ready(context.Background())
+	if statuses[0].ConsecutiveFailures != 0 {
+		t.Fatalf("ConsecutiveFailures = %d, want 0 after a success", statuses[0].ConsecutiveFailures)
+	}
+	if statuses[0].LastSuccess.IsZero() {
+		t.Fatalf("LastSuccess not set after a successful check")
+	}
+}
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
 	"os"
 	"time"
+
+	"github.com/Arescoreadmin/frostgate-core/supervisor-sidecar/health"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type coreHealth struct {
@@ -24,17 +28,23 @@ type coreStatus struct {
 }
 
 type supervisorStatus struct {
-	Status         string       `json:"status"`
-	CoreReachable  bool         `json:"core_reachable"`
-	CoreHealth     *coreHealth  `json:"core_health,omitempty"`
-	CoreStatus     *coreStatus  `json:"core_status,omitempty"`
-	LastCheck      time.Time    `json:"last_check"`
-	Errors         []string     `json:"errors,omitempty"`
-	EnforcementMode string      `json:"enforcement_mode,omitempty"`
+	Status          string         `json:"status"`
+	QuorumPolicy    string         `json:"quorum_policy"`
+	ReachableCount  int            `json:"reachable_count"`
+	TargetCount     int            `json:"target_count"`
+	SplitBrain      bool           `json:"split_brain"`
+	EnforcementMode string         `json:"enforcement_mode,omitempty"`
+	Cores           []targetResult `json:"cores"`
+	LastCheck       time.Time      `json:"last_check"`
+	StaleFor        string         `json:"stale_for,omitempty"`
+	Errors          []*probeError  `json:"errors,omitempty"`
 }
 
 var (
-	coreBaseURL string
+	coreTargets []target
+	checker     *health.Checker
+	corePoller  *poller
+	events      = newEventBus(256)
 )
 
 func getenv(key, def string) string {
@@ -44,23 +54,6 @@ func getenv(key, def string) string {
 	return def
 }
 
-func fetchJSON(url string, target interface{}) error {
-	client := &http.Client{
-		Timeout: 2 * time.Second,
-	}
-	resp, err := client.Get(url)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		return &httpError{StatusCode: resp.StatusCode}
-	}
-
-	return json.NewDecoder(resp.Body).Decode(target)
-}
-
 type httpError struct {
 	StatusCode int
 }
@@ -70,59 +63,155 @@ func (e *httpError) Error() string {
 }
 
 func handleHealth(w http.ResponseWriter, r *http.Request) {
+	reqID := requestIDFor(w, r)
 	w.Header().Set("Content-Type", "application/json")
 	resp := map[string]string{
-		"status": "ok",
-		"component": "supervisor-sidecar",
+		"status":     "ok",
+		"component":  "supervisor-sidecar",
+		"request_id": reqID,
 	}
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
-func handleSupervisorStatus(w http.ResponseWriter, r *http.Request) {
+// handleLivez reports process-local liveness only: it never calls out to the
+// core, so it stays meaningful even when the core or network is down.
+func handleLivez(w http.ResponseWriter, r *http.Request) {
+	reqID := requestIDFor(w, r)
 	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok", "request_id": reqID})
+}
 
-	status := supervisorStatus{
-		Status:        "degraded",
-		CoreReachable: false,
-		LastCheck:     time.Now().UTC(),
-		Errors:        []string{},
+// handleReadyz runs every registered check and returns the aggregated
+// readiness result. A failing critical check returns an RFC 7807 problem
+// response; failing advisory checks are reported but leave readiness at 200.
+func handleReadyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	reqID := requestIDFor(w, r)
+
+	ready, statuses := checker.Ready(ctx)
+	if !ready {
+		writeProblem(w, r, reqID, http.StatusServiceUnavailable, "Not Ready", "one or more critical checks are failing", map[string]interface{}{
+			"checks": statuses,
+		})
+		return
 	}
 
-	// /health
-	var h coreHealth
-	if err := fetchJSON(coreBaseURL+"/health", &h); err != nil {
-		status.Errors = append(status.Errors, "health: "+err.Error())
-	} else {
-		status.CoreReachable = true
-		status.CoreHealth = &h
-		status.EnforcementMode = h.EnforcementMode
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"ready":      ready,
+		"checks":     statuses,
+		"request_id": reqID,
+	})
+}
+
+// handleSupervisorStatus serves the poller's cached result instead of
+// calling out to the core synchronously, so a slow core can't stall probes
+// or create thundering-herd load.
+func handleSupervisorStatus(w http.ResponseWriter, r *http.Request) {
+	reqID := requestIDFor(w, r)
+	status := corePoller.snapshot()
+
+	if status.SplitBrain {
+		writeProblem(w, r, reqID, http.StatusConflict, "Split Brain", "core targets disagree on enforcement_mode", map[string]interface{}{
+			"cores":  status.Cores,
+			"errors": status.Errors,
+		})
+		return
 	}
 
-	// /status
-	var s coreStatus
-	if err := fetchJSON(coreBaseURL+"/status", &s); err != nil {
-		status.Errors = append(status.Errors, "status: "+err.Error())
-	} else {
-		status.CoreStatus = &s
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		supervisorStatus
+		RequestID string `json:"request_id"`
+	}{status, reqID})
+}
+
+// registerChecks wires up the concrete Checkable probes: each target's
+// /health and /status are critical since they gate enforcement-mode
+// reporting, the rest are advisory so a full disk or an aging cert degrades
+// readiness without failing it outright. core_health/core_status read p's
+// cached snapshot rather than dialing out, so /readyz stays as cheap as
+// /supervisor/status instead of firing 2×len(targets) live outbound calls on
+// every kubelet hit. client is used by the anchor check, which doesn't go
+// through the poller; main builds it from the same TLS config as the poller.
+// sigVerifier is not used by any check registered here.
+func registerChecks(c *health.Checker, p *poller, client *http.Client, sigVerifier health.SignatureVerifier) {
+	for _, t := range coreTargets {
+		t := t
+		c.Register(&pollerCachedCheck{checkName: "core_health:" + t.Name, targetName: t.Name, poller: p, healthy: func(r targetResult) bool { return r.Reachable }}, true)
+		c.Register(&pollerCachedCheck{checkName: "core_status:" + t.Name, targetName: t.Name, poller: p, healthy: func(r targetResult) bool { return r.StatusPayload != nil }}, true)
 	}
 
-	if status.CoreReachable && len(status.Errors) == 0 {
-		status.Status = "ok"
+	c.Register(&health.DiskSpaceCheck{
+		Path:         getenv("FG_DISK_CHECK_PATH", "/"),
+		MinFreeBytes: 100 * 1024 * 1024,
+	}, false)
+
+	if addr := getenv("FG_TLS_CHECK_ADDR", ""); addr != "" {
+		c.Register(&health.TLSCertExpiryCheck{Addr: addr, WarnBefore: 14 * 24 * time.Hour}, false)
 	}
 
-	_ = json.NewEncoder(w).Encode(status)
+	if anchorURL := getenv("FG_ANCHOR_URL", ""); anchorURL != "" {
+		c.Register(&health.AnchorReachabilityCheck{URL: anchorURL, Client: client}, false)
+	}
 }
 
 func main() {
 	log.Println("FrostGate supervisor-sidecar starting...")
 
-	coreBaseURL = getenv("FG_CORE_BASE_URL", "http://127.0.0.1:8080")
 	addr := getenv("SUPERVISOR_LISTEN_ADDR", ":9090")
 
+	targets, err := parseTargets(getenv("FG_CORE_TARGETS", ""), getenv("FG_CORE_BASE_URL", "http://127.0.0.1:8080"))
+	if err != nil {
+		log.Fatalf("invalid FG_CORE_TARGETS: %v", err)
+	}
+	coreTargets = targets
+
+	policy, err := parseQuorumPolicy(getenv("FG_QUORUM", ""))
+	if err != nil {
+		log.Fatalf("invalid FG_QUORUM: %v", err)
+	}
+
+	tlsConfig, err := loadCoreTLSConfig()
+	if err != nil {
+		log.Fatalf("invalid core TLS configuration: %v", err)
+	}
+
+	sigVerifier, err := newSignatureVerifier(getenv("FG_CORE_SIGNATURE_PUBKEY", ""))
+	if err != nil {
+		log.Fatalf("invalid core signature configuration: %v", err)
+	}
+
+	coreClient := &http.Client{Timeout: 2 * time.Second}
+	if tlsConfig != nil {
+		coreClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	pollInterval, err := time.ParseDuration(getenv("FG_POLL_INTERVAL", "5s"))
+	if err != nil {
+		log.Fatalf("invalid FG_POLL_INTERVAL: %v", err)
+	}
+	corePoller = newPoller(coreTargets, policy, pollInterval, tlsConfig, sigVerifier)
+
+	checker = health.NewChecker()
+	var checkSigVerifier health.SignatureVerifier
+	if sigVerifier != nil {
+		checkSigVerifier = sigVerifier
+	}
+	registerChecks(checker, corePoller, coreClient, checkSigVerifier)
+
+	go corePoller.run(context.Background())
+
 	http.HandleFunc("/health", handleHealth)
+	http.HandleFunc("/livez", handleLivez)
+	http.HandleFunc("/readyz", handleReadyz)
 	http.HandleFunc("/supervisor/status", handleSupervisorStatus)
+	http.HandleFunc("/events", handleEvents)
+	http.Handle("/metrics", promhttp.Handler())
 
-	log.Printf("Supervisor-sidecar listening on %s, coreBaseURL=%s\n", addr, coreBaseURL)
+	log.Printf("Supervisor-sidecar listening on %s, targets=%d, quorum=%s\n", addr, len(coreTargets), policy)
 	if err := http.ListenAndServe(addr, nil); err != nil {
 		log.Fatalf("supervisor-sidecar failed: %v", err)
 	}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	probeAttemptsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "frostgate_supervisor_probe_attempts_total",
+		Help: "Total number of probe requests to the core, by target and endpoint.",
+	}, []string{"target", "endpoint"})
+
+	probeFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "frostgate_supervisor_probe_failures_total",
+		Help: "Total number of failed probe requests to the core, by target and endpoint.",
+	}, []string{"target", "endpoint"})
+
+	probeLatencySeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "frostgate_supervisor_probe_latency_seconds",
+		Help:    "Round-trip latency of probe requests to the core, by target and endpoint.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"target", "endpoint"})
+
+	coreReachableGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "frostgate_supervisor_core_reachable",
+		Help: "1 if the target answered the last probe round, 0 otherwise.",
+	}, []string{"target"})
+
+	enforcementModeGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "frostgate_supervisor_enforcement_mode",
+		Help: "1 for the quorum-agreed enforcement mode, labeled by mode.",
+	}, []string{"mode"})
+
+	lastEnforcementMode string
+)
+
+// observeProbe records a probe attempt, its outcome and latency for
+// target/endpoint.
+func observeProbe(targetName, endpoint string, latencySeconds float64, err error) {
+	probeAttemptsTotal.WithLabelValues(targetName, endpoint).Inc()
+	probeLatencySeconds.WithLabelValues(targetName, endpoint).Observe(latencySeconds)
+	if err != nil {
+		probeFailuresTotal.WithLabelValues(targetName, endpoint).Inc()
+	}
+}
+
+// setEnforcementMode updates the labeled enforcement-mode gauge, clearing
+// the previous mode's label so only the current mode reads 1. An empty mode
+// (e.g. during split-brain) clears the gauge without setting a new label.
+func setEnforcementMode(mode string) {
+	if lastEnforcementMode != "" && lastEnforcementMode != mode {
+		enforcementModeGauge.WithLabelValues(lastEnforcementMode).Set(0)
+	}
+	if mode != "" {
+		enforcementModeGauge.WithLabelValues(mode).Set(1)
+	}
+	lastEnforcementMode = mode
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	breakerFailureThreshold = 3
+	breakerCooldown         = 30 * time.Second
+	backoffMax              = 60 * time.Second
+	perTargetTimeout        = 2 * time.Second
+)
+
+// targetResult is one target's probe outcome for a single refresh round.
+type targetResult struct {
+	Name            string        `json:"name"`
+	BaseURL         string        `json:"base_url"`
+	Reachable       bool          `json:"reachable"`
+	Health          *coreHealth   `json:"health,omitempty"`
+	StatusPayload   *coreStatus   `json:"status_payload,omitempty"`
+	EnforcementMode string        `json:"enforcement_mode,omitempty"`
+	BreakerState    string        `json:"breaker_state"`
+	LatencyMS       int64         `json:"latency_ms"`
+	Errors          []*probeError `json:"errors,omitempty"`
+}
+
+// poller fans out health/status probes to every configured target on an
+// interval and serves the cached, quorum-aggregated result behind an
+// RWMutex, so a slow or down core can't stall incoming requests or cause
+// thundering-herd load.
+type poller struct {
+	targets     []target
+	client      *http.Client
+	policy      quorumPolicy
+	sigVerifier *signatureVerifier
+
+	baseInterval time.Duration
+	breakers     map[string]*circuitBreaker
+
+	mu         sync.RWMutex
+	cached     supervisorStatus
+	lastPolled time.Time
+
+	failureStreak int
+
+	prevReachable map[string]bool
+	prevModes     map[string]string
+	prevBreakers  map[string]string
+}
+
+func newPoller(targets []target, policy quorumPolicy, baseInterval time.Duration, tlsConfig *tls.Config, sigVerifier *signatureVerifier) *poller {
+	breakers := make(map[string]*circuitBreaker, len(targets))
+	for _, t := range targets {
+		breakers[t.Name] = newCircuitBreaker(breakerFailureThreshold, breakerCooldown)
+	}
+
+	client := &http.Client{Timeout: perTargetTimeout}
+	if tlsConfig != nil {
+		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	return &poller{
+		targets:      targets,
+		client:       client,
+		policy:       policy,
+		sigVerifier:  sigVerifier,
+		baseInterval: baseInterval,
+		breakers:     breakers,
+		cached: supervisorStatus{
+			Status:       "degraded",
+			QuorumPolicy: string(policy),
+			Errors:       []*probeError{},
+		},
+		prevReachable: make(map[string]bool, len(targets)),
+		prevModes:     make(map[string]string, len(targets)),
+		prevBreakers:  make(map[string]string, len(targets)),
+	}
+}
+
+// run polls in a loop until ctx is canceled, sleeping for the current
+// backoff interval between refreshes.
+func (p *poller) run(ctx context.Context) {
+	p.refresh()
+	for {
+		interval := p.nextInterval()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+			p.refresh()
+		}
+	}
+}
+
+// nextInterval applies exponential backoff with jitter while quorum is
+// unsatisfied, and resets to baseInterval once it recovers.
+func (p *poller) nextInterval() time.Duration {
+	p.mu.RLock()
+	streak := p.failureStreak
+	p.mu.RUnlock()
+
+	if streak == 0 {
+		return p.baseInterval
+	}
+
+	backoff := p.baseInterval
+	for i := 0; i < streak && backoff < backoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > backoffMax {
+		backoff = backoffMax
+	}
+	jitter := time.Duration(rand.Int63n(int64(backoff) / 2))
+	return backoff/2 + jitter
+}
+
+func (p *poller) refresh() {
+	results := make([]targetResult, len(p.targets))
+	var wg sync.WaitGroup
+	for i, t := range p.targets {
+		wg.Add(1)
+		go func(i int, t target) {
+			defer wg.Done()
+			results[i] = p.probeTarget(t)
+		}(i, t)
+	}
+	wg.Wait()
+
+	reachable := 0
+	modes := map[string]bool{}
+	var errs []*probeError
+	for _, res := range results {
+		if res.Reachable {
+			reachable++
+		}
+		if res.EnforcementMode != "" {
+			modes[res.EnforcementMode] = true
+		}
+		errs = append(errs, res.Errors...)
+	}
+
+	splitBrain := len(modes) > 1
+	var aggregatedMode string
+	if !splitBrain {
+		for m := range modes {
+			aggregatedMode = m
+		}
+	} else {
+		errs = append(errs, &probeError{
+			Code:      CodeSplitBrain,
+			Phase:     "quorum",
+			Message:   "targets disagree on enforcement_mode",
+			Retryable: false,
+			Timestamp: time.Now().UTC(),
+		})
+	}
+
+	satisfied := p.policy.satisfied(reachable, len(p.targets))
+
+	status := supervisorStatus{
+		Status:          "degraded",
+		QuorumPolicy:    string(p.policy),
+		ReachableCount:  reachable,
+		TargetCount:     len(p.targets),
+		SplitBrain:      splitBrain,
+		EnforcementMode: aggregatedMode,
+		Cores:           results,
+		LastCheck:       time.Now().UTC(),
+		Errors:          errs,
+	}
+	if satisfied && !splitBrain {
+		status.Status = "ok"
+	}
+
+	setEnforcementMode(aggregatedMode)
+	p.publishTransitions(results, splitBrain)
+
+	p.commit(status, satisfied)
+}
+
+func (p *poller) probeTarget(t target) targetResult {
+	breaker := p.breakers[t.Name]
+	res := targetResult{Name: t.Name, BaseURL: t.BaseURL, BreakerState: breaker.String()}
+	start := time.Now()
+
+	if !breaker.allow() {
+		res.Errors = append(res.Errors, &probeError{
+			Code:      CodeCoreUnreachable,
+			Target:    t.Name,
+			Phase:     "breaker",
+			Message:   "circuit breaker open, skipping probe",
+			Retryable: true,
+			Timestamp: time.Now().UTC(),
+		})
+		coreReachableGauge.WithLabelValues(t.Name).Set(0)
+		return res
+	}
+
+	ok := true
+
+	var h coreHealth
+	if err := p.fetchJSON(t.Name, "health", t.BaseURL+"/health", &h); err != nil {
+		res.Errors = append(res.Errors, classifyProbeError(t.Name, "health", err))
+		ok = false
+	} else {
+		res.Reachable = true
+		res.Health = &h
+		res.EnforcementMode = h.EnforcementMode
+	}
+
+	var s coreStatus
+	if err := p.fetchJSON(t.Name, "status", t.BaseURL+"/status", &s); err != nil {
+		res.Errors = append(res.Errors, classifyProbeError(t.Name, "status", err))
+		ok = false
+	} else {
+		res.StatusPayload = &s
+	}
+
+	if ok {
+		breaker.recordSuccess()
+	} else {
+		breaker.recordFailure()
+	}
+	res.BreakerState = breaker.String()
+	res.LatencyMS = time.Since(start).Milliseconds()
+
+	coreReachableGauge.WithLabelValues(t.Name).Set(boolToFloat(res.Reachable))
+	return res
+}
+
+// publishTransitions emits an event for each observed state change since the
+// previous refresh: per-target reachability, breaker state, and the
+// quorum-aggregated split-brain condition.
+func (p *poller) publishTransitions(results []targetResult, splitBrain bool) {
+	p.mu.Lock()
+	prevSplitBrain := p.cached.SplitBrain
+	for _, res := range results {
+		prevReachable := p.prevReachable[res.Name]
+		prevMode := p.prevModes[res.Name]
+		prevBreaker := p.prevBreakers[res.Name]
+		p.prevReachable[res.Name] = res.Reachable
+		p.prevModes[res.Name] = res.EnforcementMode
+		p.prevBreakers[res.Name] = res.BreakerState
+
+		if res.Reachable != prevReachable {
+			if res.Reachable {
+				events.publish(EventCoreReachable, map[string]string{"target": res.Name})
+			} else {
+				events.publish(EventCoreUnreachable, map[string]interface{}{"target": res.Name, "errors": res.Errors})
+			}
+		}
+		if res.BreakerState != prevBreaker {
+			switch res.BreakerState {
+			case breakerOpen.String():
+				events.publish(EventBreakerOpened, map[string]string{"target": res.Name})
+			case breakerClosed.String():
+				if prevBreaker != "" {
+					events.publish(EventBreakerClosed, map[string]string{"target": res.Name})
+				}
+			}
+		}
+		if res.EnforcementMode != "" && res.EnforcementMode != prevMode {
+			events.publish(EventEnforcementModeChanged, map[string]string{"target": res.Name, "from": prevMode, "to": res.EnforcementMode})
+		}
+	}
+	p.mu.Unlock()
+
+	if splitBrain != prevSplitBrain && splitBrain {
+		events.publish(EventSplitBrain, nil)
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (p *poller) commit(status supervisorStatus, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cached = status
+	p.lastPolled = time.Now()
+	if ok {
+		p.failureStreak = 0
+	} else {
+		p.failureStreak++
+	}
+}
+
+// snapshot returns the cached status with StaleFor populated against the
+// current time, so callers can tell how old the cached probe is.
+func (p *poller) snapshot() supervisorStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	status := p.cached
+	status.StaleFor = time.Since(p.lastPolled).Round(time.Millisecond).String()
+	return status
+}
+
+// pollerCachedCheck implements health.Checkable by reading a single target's
+// result from the poller's cached snapshot instead of dialing the core
+// again: wiring /readyz straight to HTTPJSONCheck would have it fire
+// 2×len(targets) live outbound calls on every kubelet hit, recreating the
+// synchronous thundering-herd problem the poller was built to eliminate for
+// /supervisor/status.
+type pollerCachedCheck struct {
+	checkName  string
+	targetName string
+	poller     *poller
+	healthy    func(targetResult) bool
+}
+
+func (c *pollerCachedCheck) Name() string { return c.checkName }
+
+func (c *pollerCachedCheck) Healthy(ctx context.Context) error {
+	for _, res := range c.poller.snapshot().Cores {
+		if res.Name != c.targetName {
+			continue
+		}
+		if c.healthy(res) {
+			return nil
+		}
+		return fmt.Errorf("target %s: cached probe unhealthy (breaker=%s)", c.targetName, res.BreakerState)
+	}
+	return fmt.Errorf("target %s: no cached probe result yet", c.targetName)
+}
+
+func (p *poller) fetchJSON(targetName, endpoint, url string, dst interface{}) (err error) {
+	start := time.Now()
+	defer func() { observeProbe(targetName, endpoint, time.Since(start).Seconds(), err) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), perTargetTimeout)
+	defer cancel()
+
+	req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if reqErr != nil {
+		err = reqErr
+		return err
+	}
+	resp, respErr := p.client.Do(req)
+	if respErr != nil {
+		err = respErr
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		err = &httpError{StatusCode: resp.StatusCode}
+		return err
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	if readErr != nil {
+		err = readErr
+		return err
+	}
+
+	if p.sigVerifier != nil {
+		if sigErr := p.sigVerifier.Verify(body, resp.Header.Get("X-FrostGate-Signature")); sigErr != nil {
+			err = sigErr
+			return err
+		}
+	}
+
+	err = json.Unmarshal(body, dst)
+	return err
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFakeCore starts an httptest server answering /health and /status with
+// the given enforcement mode, and counts how many requests it receives.
+func newFakeCore(mode string) (srv *httptest.Server, requests *int64) {
+	requests = new(int64)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(requests, 1)
+		_ = json.NewEncoder(w).Encode(coreHealth{Status: "ok", Env: "test", EnforcementMode: mode})
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(requests, 1)
+		_ = json.NewEncoder(w).Encode(coreStatus{Service: "core", Version: "v1", Env: "test", EnforcementMode: mode})
+	})
+	return httptest.NewServer(mux), requests
+}
+
+func newTestPoller(targets []target, policy quorumPolicy) *poller {
+	return newPoller(targets, policy, time.Second, nil, nil)
+}
+
+func TestPollerRefreshDetectsSplitBrain(t *testing.T) {
+	a, _ := newFakeCore("enforce")
+	defer a.Close()
+	b, _ := newFakeCore("monitor")
+	defer b.Close()
+
+	p := newTestPoller([]target{{Name: "a", BaseURL: a.URL}, {Name: "b", BaseURL: b.URL}}, quorumMajority)
+	p.refresh()
+
+	status := p.snapshot()
+	if !status.SplitBrain {
+		t.Fatalf("SplitBrain = false, want true when targets disagree on enforcement_mode")
+	}
+	if status.EnforcementMode != "" {
+		t.Fatalf("EnforcementMode = %q, want empty during split-brain", status.EnforcementMode)
+	}
+	foundSplitBrainErr := false
+	for _, e := range status.Errors {
+		if e.Code == CodeSplitBrain {
+			foundSplitBrainErr = true
+		}
+	}
+	if !foundSplitBrainErr {
+		t.Fatalf("Errors = %+v, want a %s entry", status.Errors, CodeSplitBrain)
+	}
+}
+
+func TestPollerRefreshAgreeingTargetsAreNotSplitBrain(t *testing.T) {
+	a, _ := newFakeCore("enforce")
+	defer a.Close()
+	b, _ := newFakeCore("enforce")
+	defer b.Close()
+
+	p := newTestPoller([]target{{Name: "a", BaseURL: a.URL}, {Name: "b", BaseURL: b.URL}}, quorumAll)
+	p.refresh()
+
+	status := p.snapshot()
+	if status.SplitBrain {
+		t.Fatalf("SplitBrain = true, want false when targets agree")
+	}
+	if status.EnforcementMode != "enforce" {
+		t.Fatalf("EnforcementMode = %q, want enforce", status.EnforcementMode)
+	}
+	if status.Status != "ok" {
+		t.Fatalf("Status = %q, want ok when quorum is satisfied and not split-brain", status.Status)
+	}
+}
+
+func TestPollerRefreshQuorumAcrossTargetCounts(t *testing.T) {
+	up, _ := newFakeCore("enforce")
+	defer up.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	cases := []struct {
+		name       string
+		policy     quorumPolicy
+		wantStatus string
+	}{
+		{name: "majority satisfied by 2 of 3", policy: quorumMajority, wantStatus: "ok"},
+		{name: "all requires every target", policy: quorumAll, wantStatus: "degraded"},
+		{name: "any is satisfied by 1 reachable", policy: quorumAny, wantStatus: "ok"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			targets := []target{
+				{Name: "a", BaseURL: up.URL},
+				{Name: "b", BaseURL: up.URL},
+				{Name: "c", BaseURL: down.URL},
+			}
+			p := newTestPoller(targets, tc.policy)
+			p.refresh()
+
+			status := p.snapshot()
+			if status.ReachableCount != 2 {
+				t.Fatalf("ReachableCount = %d, want 2", status.ReachableCount)
+			}
+			if status.Status != tc.wantStatus {
+				t.Fatalf("Status = %q, want %q", status.Status, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPollerProbeTargetSkipsWhenBreakerOpen(t *testing.T) {
+	srv, requests := newFakeCore("enforce")
+	defer srv.Close()
+
+	tgt := target{Name: "a", BaseURL: srv.URL}
+	p := newTestPoller([]target{tgt}, quorumAny)
+	p.breakers[tgt.Name].state = breakerOpen
+	p.breakers[tgt.Name].openedAt = time.Now()
+
+	res := p.probeTarget(tgt)
+
+	if atomic.LoadInt64(requests) != 0 {
+		t.Fatalf("probeTarget dialed out %d times, want 0 while breaker is open", atomic.LoadInt64(requests))
+	}
+	if res.Reachable {
+		t.Fatalf("Reachable = true, want false when the breaker skipped the probe")
+	}
+	if len(res.Errors) != 1 || res.Errors[0].Phase != "breaker" {
+		t.Fatalf("Errors = %+v, want a single breaker-phase error", res.Errors)
+	}
+}
+
+func TestPollerProbeTargetClosesBreakerAfterSuccess(t *testing.T) {
+	srv, _ := newFakeCore("enforce")
+	defer srv.Close()
+
+	tgt := target{Name: "a", BaseURL: srv.URL}
+	p := newTestPoller([]target{tgt}, quorumAny)
+
+	res := p.probeTarget(tgt)
+	if !res.Reachable || res.BreakerState != breakerClosed.String() {
+		t.Fatalf("got reachable=%v breaker=%s, want reachable=true breaker=closed", res.Reachable, res.BreakerState)
+	}
+}
+
+func TestPollerSnapshotReportsStaleFor(t *testing.T) {
+	srv, _ := newFakeCore("enforce")
+	defer srv.Close()
+
+	p := newTestPoller([]target{{Name: "a", BaseURL: srv.URL}}, quorumAny)
+	p.refresh()
+
+	status := p.snapshot()
+	if status.StaleFor == "" {
+		t.Fatalf("StaleFor not populated on snapshot")
+	}
+}
+
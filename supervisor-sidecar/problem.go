@@ -0,0 +1,85 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+)
+
+// problemDetails is an RFC 7807 application/problem+json body, with
+// request_id as a non-standard extension member for correlating with core
+// logs. extra carries additional non-standard extension members (e.g. the
+// per-check statuses or core results that drove the failure) flattened into
+// the top-level object, per RFC 7807 section 3.2.
+type problemDetails struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+
+	extra map[string]interface{}
+}
+
+// MarshalJSON flattens extra alongside the standard RFC 7807 members so
+// callers get diagnostic detail (e.g. "checks" or "cores") without consumers
+// having to unwrap a nested envelope.
+func (p problemDetails) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(p.extra)+6)
+	for k, v := range p.extra {
+		fields[k] = v
+	}
+	fields["type"] = p.Type
+	fields["title"] = p.Title
+	fields["status"] = p.Status
+	if p.Detail != "" {
+		fields["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		fields["instance"] = p.Instance
+	}
+	if p.RequestID != "" {
+		fields["request_id"] = p.RequestID
+	}
+	return json.Marshal(fields)
+}
+
+// writeProblem writes an RFC 7807 problem response for a non-2xx result.
+// reqID must come from a prior requestIDFor call so exactly one id is
+// generated and echoed per request, even when writeProblem is reached after
+// other response fields were already computed. extra is merged into the
+// response body as additional extension members (e.g. per-check statuses);
+// pass nil when the failure carries no further diagnostic payload.
+func writeProblem(w http.ResponseWriter, r *http.Request, reqID string, status int, title, detail string, extra map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problemDetails{
+		Type:      "about:blank",
+		Title:     title,
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		RequestID: reqID,
+		extra:     extra,
+	})
+}
+
+// requestIDFor returns the request's X-Request-ID, generating one if the
+// client didn't send it, and echoes it back on the response so success and
+// error paths alike can be correlated with core logs.
+func requestIDFor(w http.ResponseWriter, r *http.Request) string {
+	id := r.Header.Get("X-Request-ID")
+	if id == "" {
+		id = generateRequestID()
+	}
+	w.Header().Set("X-Request-ID", id)
+	return id
+}
+
+func generateRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
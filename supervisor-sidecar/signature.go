@@ -0,0 +1,60 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// signatureError marks a failure to verify a core response's detached
+// signature, distinct from transport or decode errors so it can be handled
+// as its own class.
+type signatureError struct {
+	msg string
+}
+
+func (e *signatureError) Error() string { return e.msg }
+
+// signatureVerifier checks the X-FrostGate-Signature header on core
+// responses against a configured ed25519 public key.
+type signatureVerifier struct {
+	publicKey ed25519.PublicKey
+}
+
+// newSignatureVerifier decodes a base64-encoded ed25519 public key from
+// FG_CORE_SIGNATURE_PUBKEY. An empty key disables verification, returning a
+// nil verifier.
+func newSignatureVerifier(pubKeyB64 string) (*signatureVerifier, error) {
+	if pubKeyB64 == "" {
+		return nil, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(pubKeyB64)
+	if err != nil {
+		return nil, fmt.Errorf("decode FG_CORE_SIGNATURE_PUBKEY: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("FG_CORE_SIGNATURE_PUBKEY: want %d bytes, got %d", ed25519.PublicKeySize, len(raw))
+	}
+	return &signatureVerifier{publicKey: ed25519.PublicKey(raw)}, nil
+}
+
+// Verify checks header (the X-FrostGate-Signature value, e.g.
+// "ed25519,<base64 signature>") against body.
+func (v *signatureVerifier) Verify(body []byte, header string) error {
+	if header == "" {
+		return &signatureError{msg: "missing X-FrostGate-Signature header"}
+	}
+	parts := strings.SplitN(header, ",", 2)
+	if len(parts) != 2 || parts[0] != "ed25519" {
+		return &signatureError{msg: fmt.Sprintf("unsupported signature scheme %q", header)}
+	}
+	sig, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return &signatureError{msg: "malformed signature encoding: " + err.Error()}
+	}
+	if !ed25519.Verify(v.publicKey, body, sig) {
+		return &signatureError{msg: "signature verification failed"}
+	}
+	return nil
+}
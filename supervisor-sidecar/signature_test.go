@@ -0,0 +1,66 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+)
+
+func TestNewSignatureVerifier(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	pubB64 := base64.StdEncoding.EncodeToString(pub)
+
+	if v, err := newSignatureVerifier(""); err != nil || v != nil {
+		t.Fatalf("newSignatureVerifier(\"\") = (%v, %v), want (nil, nil)", v, err)
+	}
+	if _, err := newSignatureVerifier("not-base64!"); err == nil {
+		t.Fatalf("newSignatureVerifier(invalid base64) = nil error, want error")
+	}
+	if _, err := newSignatureVerifier(base64.StdEncoding.EncodeToString([]byte("too-short"))); err == nil {
+		t.Fatalf("newSignatureVerifier(wrong length key) = nil error, want error")
+	}
+	v, err := newSignatureVerifier(pubB64)
+	if err != nil {
+		t.Fatalf("newSignatureVerifier(valid key): %v", err)
+	}
+	if v == nil {
+		t.Fatalf("newSignatureVerifier(valid key) = nil, want non-nil verifier")
+	}
+}
+
+func TestSignatureVerifierVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	v := &signatureVerifier{publicKey: pub}
+
+	body := []byte(`{"status":"ok"}`)
+	sig := ed25519.Sign(priv, body)
+	header := "ed25519," + base64.StdEncoding.EncodeToString(sig)
+
+	if err := v.Verify(body, header); err != nil {
+		t.Fatalf("Verify(valid signature) = %v, want nil", err)
+	}
+
+	cases := []struct {
+		name   string
+		body   []byte
+		header string
+	}{
+		{"missing header", body, ""},
+		{"unsupported scheme", body, "hmac-sha256," + base64.StdEncoding.EncodeToString(sig)},
+		{"malformed encoding", body, "ed25519,not-base64!"},
+		{"tampered body", []byte(`{"status":"bad"}`), header},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := v.Verify(tc.body, tc.header); err == nil {
+				t.Fatalf("Verify(%s) = nil, want error", tc.name)
+			}
+		})
+	}
+}
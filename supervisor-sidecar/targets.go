@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// target is one core endpoint the sidecar supervises.
+type target struct {
+	Name    string
+	BaseURL string
+}
+
+// parseTargets parses FG_CORE_TARGETS in "name1=url1,name2=url2" form. An
+// empty raw value falls back to a single target named "core" pointing at
+// fallbackURL, so single-core deployments need no extra configuration.
+func parseTargets(raw, fallbackURL string) ([]target, error) {
+	if strings.TrimSpace(raw) == "" {
+		return []target{{Name: "core", BaseURL: fallbackURL}}, nil
+	}
+
+	var targets []target
+	seen := make(map[string]bool)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid FG_CORE_TARGETS entry %q, want name=url", pair)
+		}
+		name := parts[0]
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate FG_CORE_TARGETS name %q", name)
+		}
+		seen[name] = true
+		targets = append(targets, target{Name: name, BaseURL: parts[1]})
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("FG_CORE_TARGETS produced no targets")
+	}
+	return targets, nil
+}
+
+// quorumPolicy decides the aggregated status from per-target reachability.
+type quorumPolicy string
+
+const (
+	quorumMajority quorumPolicy = "majority"
+	quorumAll      quorumPolicy = "all"
+	quorumAny      quorumPolicy = "any"
+)
+
+func parseQuorumPolicy(raw string) (quorumPolicy, error) {
+	switch quorumPolicy(raw) {
+	case quorumMajority, quorumAll, quorumAny:
+		return quorumPolicy(raw), nil
+	case "":
+		return quorumMajority, nil
+	default:
+		return "", fmt.Errorf("invalid FG_QUORUM %q, want majority|all|any", raw)
+	}
+}
+
+// satisfied reports whether reachable out of total targets satisfies p.
+func (p quorumPolicy) satisfied(reachable, total int) bool {
+	if total == 0 {
+		return false
+	}
+	switch p {
+	case quorumAll:
+		return reachable == total
+	case quorumAny:
+		return reachable > 0
+	default: // majority
+		return reachable*2 > total
+	}
+}
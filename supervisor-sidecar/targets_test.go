@@ -0,0 +1,128 @@
+package main
+
+import "testing"
+
+func TestParseTargets(t *testing.T) {
+	cases := []struct {
+		name        string
+		raw         string
+		fallbackURL string
+		want        []target
+		wantErr     bool
+	}{
+		{
+			name:        "empty falls back to single core target",
+			raw:         "",
+			fallbackURL: "http://127.0.0.1:8080",
+			want:        []target{{Name: "core", BaseURL: "http://127.0.0.1:8080"}},
+		},
+		{
+			name: "multiple targets",
+			raw:  "a=http://a:8080,b=http://b:8080",
+			want: []target{
+				{Name: "a", BaseURL: "http://a:8080"},
+				{Name: "b", BaseURL: "http://b:8080"},
+			},
+		},
+		{
+			name: "whitespace around entries is trimmed",
+			raw:  " a=http://a:8080 , b=http://b:8080 ",
+			want: []target{
+				{Name: "a", BaseURL: "http://a:8080"},
+				{Name: "b", BaseURL: "http://b:8080"},
+			},
+		},
+		{
+			name:    "missing equals is an error",
+			raw:     "a-http://a:8080",
+			wantErr: true,
+		},
+		{
+			name:    "empty name is an error",
+			raw:     "=http://a:8080",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate name is an error",
+			raw:     "a=http://a:8080,a=http://a2:8080",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseTargets(tc.raw, tc.fallbackURL)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseTargets(%q) = %v, want error", tc.raw, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTargets(%q) unexpected error: %v", tc.raw, err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("parseTargets(%q) = %v, want %v", tc.raw, got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("parseTargets(%q)[%d] = %+v, want %+v", tc.raw, i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestQuorumPolicySatisfied(t *testing.T) {
+	cases := []struct {
+		policy    quorumPolicy
+		reachable int
+		total     int
+		want      bool
+	}{
+		{quorumMajority, 2, 3, true},
+		{quorumMajority, 1, 3, false},
+		{quorumMajority, 0, 0, false},
+		{quorumAll, 3, 3, true},
+		{quorumAll, 2, 3, false},
+		{quorumAny, 1, 3, true},
+		{quorumAny, 0, 3, false},
+	}
+
+	for _, tc := range cases {
+		got := tc.policy.satisfied(tc.reachable, tc.total)
+		if got != tc.want {
+			t.Errorf("%s.satisfied(%d, %d) = %v, want %v", tc.policy, tc.reachable, tc.total, got, tc.want)
+		}
+	}
+}
+
+func TestParseQuorumPolicy(t *testing.T) {
+	cases := []struct {
+		raw     string
+		want    quorumPolicy
+		wantErr bool
+	}{
+		{"", quorumMajority, false},
+		{"majority", quorumMajority, false},
+		{"all", quorumAll, false},
+		{"any", quorumAny, false},
+		{"bogus", "", true},
+	}
+
+	for _, tc := range cases {
+		got, err := parseQuorumPolicy(tc.raw)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parseQuorumPolicy(%q) = %v, want error", tc.raw, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseQuorumPolicy(%q) unexpected error: %v", tc.raw, err)
+		}
+		if got != tc.want {
+			t.Errorf("parseQuorumPolicy(%q) = %v, want %v", tc.raw, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// loadCoreTLSConfig builds the TLS configuration used to talk to core
+// targets from FG_CORE_CA, FG_CORE_CLIENT_CERT, FG_CORE_CLIENT_KEY and
+// FG_CORE_PINNED_SPKI. It returns (nil, nil) when none of those are set, so
+// callers fall back to the default transport for plain-HTTP deployments.
+func loadCoreTLSConfig() (*tls.Config, error) {
+	caPath := getenv("FG_CORE_CA", "")
+	certPath := getenv("FG_CORE_CLIENT_CERT", "")
+	keyPath := getenv("FG_CORE_CLIENT_KEY", "")
+	pinned := getenv("FG_CORE_PINNED_SPKI", "")
+
+	if caPath == "" && certPath == "" && keyPath == "" && pinned == "" {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{}
+
+	if caPath != "" {
+		pemBytes, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("read FG_CORE_CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("FG_CORE_CA: no certificates found in %s", caPath)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if certPath != "" || keyPath != "" {
+		if certPath == "" || keyPath == "" {
+			return nil, fmt.Errorf("FG_CORE_CLIENT_CERT and FG_CORE_CLIENT_KEY must both be set")
+		}
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("load client cert/key: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if pinned != "" {
+		pins := make(map[string]bool)
+		for _, p := range strings.Split(pinned, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				pins[p] = true
+			}
+		}
+		cfg.VerifyPeerCertificate = verifyPinnedSPKI(pins)
+	}
+
+	return cfg, nil
+}
+
+// verifyPinnedSPKI returns a tls.Config.VerifyPeerCertificate callback that
+// accepts the connection only if some presented certificate's SPKI SHA-256
+// hash (base64-encoded) is in pins. It runs in addition to, not instead of,
+// normal chain verification.
+func verifyPinnedSPKI(pins map[string]bool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		for _, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				continue
+			}
+			sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+			if pins[base64.StdEncoding.EncodeToString(sum[:])] {
+				return nil
+			}
+		}
+		return fmt.Errorf("no peer certificate matched a pinned SPKI hash")
+	}
+}